@@ -0,0 +1,11 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package codec holds the wire-level constants shared across the simulator
+// and the programs it runs.
+package codec
+
+// AddressLen is the length, in bytes, of an address: one prefix byte
+// identifying the key type it was derived from, followed by the key
+// material itself.
+const AddressLen = 33
@@ -0,0 +1,117 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import "context"
+
+// change is one undo record: the value key held immediately before a write,
+// so RevertToSnapshot can restore it.
+type change struct {
+	key        string
+	hadValue   bool
+	value      []byte
+	wasDeleted bool
+}
+
+// SimpleMutable layers pending writes over a read-only parent. It also
+// supports Snapshot/RevertToSnapshot, the same pattern go-ethereum's
+// SimulatedBackend.PendingCallContract uses to run a call against pending
+// state and then discard whatever it wrote: every Insert/Remove is appended
+// to an undo log, and RevertToSnapshot replays that log backwards to a
+// previously recorded position.
+type SimpleMutable struct {
+	parent  Immutable
+	storage map[string][]byte
+	deleted map[string]bool
+
+	changes []change
+}
+
+// NewSimpleMutable returns a SimpleMutable backed by parent. parent may be
+// nil, in which case SimpleMutable behaves as its own root store.
+func NewSimpleMutable(parent Immutable) *SimpleMutable {
+	return &SimpleMutable{
+		parent:  parent,
+		storage: map[string][]byte{},
+		deleted: map[string]bool{},
+	}
+}
+
+func (s *SimpleMutable) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	k := string(key)
+	if s.deleted[k] {
+		return nil, ErrNotFound
+	}
+	if v, ok := s.storage[k]; ok {
+		return v, nil
+	}
+	if s.parent == nil {
+		return nil, ErrNotFound
+	}
+	return s.parent.GetValue(ctx, key)
+}
+
+func (s *SimpleMutable) Insert(_ context.Context, key []byte, value []byte) error {
+	k := string(key)
+	s.changes = append(s.changes, s.undoFor(k))
+	s.storage[k] = value
+	delete(s.deleted, k)
+	return nil
+}
+
+func (s *SimpleMutable) Remove(_ context.Context, key []byte) error {
+	k := string(key)
+	s.changes = append(s.changes, s.undoFor(k))
+	delete(s.storage, k)
+	s.deleted[k] = true
+	return nil
+}
+
+func (s *SimpleMutable) undoFor(k string) change {
+	if v, ok := s.storage[k]; ok {
+		return change{key: k, hadValue: true, value: v}
+	}
+	return change{key: k, wasDeleted: s.deleted[k]}
+}
+
+// Snapshot returns an identifier RevertToSnapshot can later roll back to.
+func (s *SimpleMutable) Snapshot() int {
+	return len(s.changes)
+}
+
+// RevertToSnapshot undoes every write made since Snapshot returned id,
+// restoring each touched key to the value (or absence) it had at that
+// point.
+func (s *SimpleMutable) RevertToSnapshot(id int) {
+	for i := len(s.changes) - 1; i >= id; i-- {
+		c := s.changes[i]
+		switch {
+		case c.hadValue:
+			s.storage[c.key] = c.value
+			delete(s.deleted, c.key)
+		case c.wasDeleted:
+			s.deleted[c.key] = true
+			delete(s.storage, c.key)
+		default:
+			delete(s.storage, c.key)
+			delete(s.deleted, c.key)
+		}
+	}
+	s.changes = s.changes[:id]
+}
+
+// Commit flushes every pending write into parent.
+func (s *SimpleMutable) Commit(ctx context.Context, parent Mutable) error {
+	for k, v := range s.storage {
+		if err := parent.Insert(ctx, []byte(k), v); err != nil {
+			return err
+		}
+	}
+	for k := range s.deleted {
+		if err := parent.Remove(ctx, []byte(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
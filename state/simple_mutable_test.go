@@ -0,0 +1,155 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// memImmutable is a trivial Immutable backed by a plain map, used to give
+// SimpleMutable a parent to read through and revert back to.
+type memImmutable map[string][]byte
+
+func (m memImmutable) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := m[string(key)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
+
+func TestSimpleMutableSnapshotRevert(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		parent Immutable
+		setup  func(db *SimpleMutable) int
+		mutate func(db *SimpleMutable)
+		key    string
+		want   []byte // nil means the key should be ErrNotFound after revert
+	}{
+		{
+			name:  "insert since snapshot is undone",
+			setup: func(db *SimpleMutable) int { return db.Snapshot() },
+			mutate: func(db *SimpleMutable) {
+				_ = db.Insert(ctx, []byte("a"), []byte("1"))
+			},
+			key:  "a",
+			want: nil,
+		},
+		{
+			name: "overwrite since snapshot restores the old value",
+			setup: func(db *SimpleMutable) int {
+				_ = db.Insert(ctx, []byte("a"), []byte("1"))
+				return db.Snapshot()
+			},
+			mutate: func(db *SimpleMutable) {
+				_ = db.Insert(ctx, []byte("a"), []byte("2"))
+			},
+			key:  "a",
+			want: []byte("1"),
+		},
+		{
+			name:   "delete of a parent-backed value is undone",
+			parent: memImmutable{"a": []byte("1")},
+			setup:  func(db *SimpleMutable) int { return db.Snapshot() },
+			mutate: func(db *SimpleMutable) {
+				_ = db.Remove(ctx, []byte("a"))
+			},
+			key:  "a",
+			want: []byte("1"),
+		},
+		{
+			name: "delete of a locally-inserted value is undone",
+			setup: func(db *SimpleMutable) int {
+				_ = db.Insert(ctx, []byte("a"), []byte("1"))
+				return db.Snapshot()
+			},
+			mutate: func(db *SimpleMutable) {
+				_ = db.Remove(ctx, []byte("a"))
+			},
+			key:  "a",
+			want: []byte("1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := NewSimpleMutable(tt.parent)
+			snap := tt.setup(db)
+			tt.mutate(db)
+			db.RevertToSnapshot(snap)
+
+			v, err := db.GetValue(ctx, []byte(tt.key))
+			if tt.want == nil {
+				if !errors.Is(err, ErrNotFound) {
+					t.Fatalf("expected ErrNotFound, got value %q err %v", v, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(v) != string(tt.want) {
+				t.Fatalf("got %q, want %q", v, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimpleMutableNestedSnapshots(t *testing.T) {
+	ctx := context.Background()
+	db := NewSimpleMutable(nil)
+
+	outer := db.Snapshot()
+	if err := db.Insert(ctx, []byte("a"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	inner := db.Snapshot()
+	if err := db.Insert(ctx, []byte("a"), []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Insert(ctx, []byte("b"), []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+
+	db.RevertToSnapshot(inner)
+	if v, err := db.GetValue(ctx, []byte("a")); err != nil || string(v) != "1" {
+		t.Fatalf("expected a=1 after inner revert, got %q err %v", v, err)
+	}
+	if _, err := db.GetValue(ctx, []byte("b")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected b to be gone after inner revert, got %v", err)
+	}
+
+	db.RevertToSnapshot(outer)
+	if _, err := db.GetValue(ctx, []byte("a")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a to be gone after outer revert, got %v", err)
+	}
+}
+
+func TestSimpleMutableCommit(t *testing.T) {
+	ctx := context.Background()
+	db := NewSimpleMutable(nil)
+	if err := db.Insert(ctx, []byte("a"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Remove(ctx, []byte("b")); err != nil {
+		t.Fatal(err)
+	}
+
+	parent := NewSimpleMutable(memImmutable{"b": []byte("2")})
+	if err := db.Commit(ctx, parent); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := parent.GetValue(ctx, []byte("a")); err != nil || string(v) != "1" {
+		t.Fatalf("expected committed a=1, got %q err %v", v, err)
+	}
+	if _, err := parent.GetValue(ctx, []byte("b")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected committed removal of b, got %v", err)
+	}
+}
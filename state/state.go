@@ -0,0 +1,28 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package state defines the minimal read/write interfaces the simulator and
+// the programs it runs operate against, independent of how the underlying
+// store is implemented.
+package state
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by Immutable.GetValue when key isn't present.
+var ErrNotFound = errors.New("not found")
+
+// Immutable is a read-only view over key/value state.
+type Immutable interface {
+	GetValue(ctx context.Context, key []byte) (value []byte, err error)
+}
+
+// Mutable additionally allows inserting and removing keys.
+type Mutable interface {
+	Immutable
+
+	Insert(ctx context.Context, key []byte, value []byte) error
+	Remove(ctx context.Context, key []byte) error
+}
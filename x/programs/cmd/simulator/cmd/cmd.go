@@ -0,0 +1,24 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+
+	"github.com/akamensky/argparse"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// Cmd is implemented by every simulator subcommand (run, ...) so
+// main can register them uniformly against a shared argparse.Parser and
+// dispatch whichever one Happened().
+type Cmd interface {
+	New(parser *argparse.Parser, programIDStrMap map[int]ids.ID, lastStep *int, reader *bufio.Reader)
+	Run(ctx context.Context, log logging.Logger, db *state.SimpleMutable, args []string) (*Response, error)
+	Happened() bool
+}
@@ -0,0 +1,16 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import "errors"
+
+var (
+	ErrInvalidPlan              = errors.New("invalid plan")
+	ErrInvalidStep              = errors.New("invalid step")
+	ErrInvalidParamType         = errors.New("invalid param type")
+	ErrInvalidEndpoint          = errors.New("invalid endpoint")
+	ErrFirstParamRequiredID     = errors.New("first param must be an id")
+	ErrFirstParamRequiredString = errors.New("first param must be a string")
+	ErrNamedKeyNotFound         = errors.New("named key not found")
+)
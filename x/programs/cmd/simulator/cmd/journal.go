@@ -0,0 +1,166 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const journalFileName = "journal.log"
+
+// JournalEntry is a single executed step recorded to the journal, carrying
+// everything needed to replay it: the resolved call (post createCallParams,
+// so "step_N"/named references are already program IDs), the txID it
+// produced, if any, and (for an EndpointKey step) the key material it
+// generated, so replay can reproduce it exactly instead of generating new,
+// different key material.
+type JournalEntry struct {
+	Step     int         `json:"step"`
+	Endpoint Endpoint    `json:"endpoint"`
+	Method   string      `json:"method"`
+	Params   []Parameter `json:"params"`
+	TxID     string      `json:"txId,omitempty"`
+	Key      []byte      `json:"key,omitempty"`
+}
+
+// Journal is an append-only log of every step executed by the simulator: a
+// crash-safe record that lets the simulator rebuild programIDStrMap and DB
+// state by replaying history on startup instead of requiring a caller to
+// persist it.
+type Journal struct {
+	path string
+	file *os.File
+	log  logging.Logger
+}
+
+// OpenJournal opens (creating if necessary) the journal file under dataDir.
+func OpenJournal(dataDir string, log logging.Logger) (*Journal, error) {
+	path := filepath.Join(dataDir, journalFileName)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{path: path, file: f, log: log}, nil
+}
+
+// Append records a single executed step. Only steps that actually succeeded
+// should be passed here: runStepFunc is deterministic, so journaling a
+// failed step would make Replay fail at the same point on every subsequent
+// startup, permanently bricking the simulator.
+func (j *Journal) Append(entry JournalEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	_, err = j.file.Write(b)
+	return err
+}
+
+// Close closes the underlying journal file.
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+// Replay re-executes every entry in the journal against a fresh db, in
+// order, reconstructing programIDStrMap the same way RunStep does for a
+// single invocation. It returns the rebuilt map and the next free step
+// index so the simulator can resume accepting new `run` commands right
+// where the journal left off.
+//
+// programCreateFunc assigns every deployed program a fresh random ID (see
+// generateRandomID), so a replayed ProgramCreate is never assigned the same
+// ID it got the first time it ran. Replay tracks that original-ID ->
+// replayed-ID mapping in idRemap and rewrites any later entry's Params that
+// reference an original ID before executing it, so references baked in by
+// the original run (e.g. an execute step's program-ID parameter) keep
+// pointing at the right program. Similarly, an EndpointKey step's recorded
+// Key is passed back into runStepFunc so the replayed key is identical to
+// the one the original run generated, rather than a new random one.
+func Replay(ctx context.Context, log logging.Logger, db *state.SimpleMutable, dataDir string) (map[int]ids.ID, int, error) {
+	path := filepath.Join(dataDir, journalFileName)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[int]ids.ID{}, 0, nil
+	} else if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	programIDStrMap := make(map[int]ids.ID)
+	idRemap := make(map[ids.ID]ids.ID)
+	lastStep := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, 0, fmt.Errorf("journal entry %d: %w", entry.Step, err)
+		}
+
+		params := remapJournalParams(entry.Params, idRemap)
+
+		resp := newResponse(entry.Step)
+		if err := runStepFunc(ctx, log, db, entry.Endpoint, 0, entry.Method, params, entry.Key, resp); err != nil {
+			return nil, 0, fmt.Errorf("replaying step %d: %w", entry.Step, err)
+		}
+
+		if replayedTxID, ok := resp.getTxID(); ok {
+			id, err := ids.FromString(replayedTxID)
+			if err != nil {
+				return nil, 0, err
+			}
+			programIDStrMap[entry.Step] = id
+
+			if entry.TxID != "" {
+				origID, err := ids.FromString(entry.TxID)
+				if err != nil {
+					return nil, 0, err
+				}
+				idRemap[origID] = id
+			}
+		}
+		if entry.Step+1 > lastStep {
+			lastStep = entry.Step + 1
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	log.Info("replayed journal", zap.Int("steps", lastStep), zap.String("path", path))
+	return programIDStrMap, lastStep, nil
+}
+
+// remapJournalParams rewrites any ID-typed parameter that names a program
+// under its original run's ID to whatever ID that program was reassigned
+// during replay.
+func remapJournalParams(params []Parameter, idRemap map[ids.ID]ids.ID) []Parameter {
+	out := make([]Parameter, len(params))
+	for i, p := range params {
+		origID, err := ids.ToID(p.Value)
+		if p.Type != ID || err != nil {
+			out[i] = p
+			continue
+		}
+		newID, ok := idRemap[origID]
+		if !ok {
+			out[i] = p
+			continue
+		}
+		out[i] = Parameter{Value: newID[:], Type: p.Type}
+	}
+	return out
+}
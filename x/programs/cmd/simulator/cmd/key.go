@@ -0,0 +1,65 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/programs/cmd/simulator/vm/storage"
+)
+
+// ErrDuplicateKeyName is returned when a key step tries to create a name
+// that's already stored.
+var ErrDuplicateKeyName = errors.New("key name already exists")
+
+// keyCreateFunc generates a new ed25519 key and stores its public key under
+// name, so later steps can reference it by name (see createCallParams's
+// KeyEd25519 case). If preset is non-nil, it's stored as-is instead of
+// generating fresh key material: Replay passes the key bytes recorded in the
+// JournalEntry so a replayed key step reproduces the exact same public key
+// (and therefore the exact same derived address) as the original run.
+func keyCreateFunc(ctx context.Context, db *state.SimpleMutable, name string, preset ed25519.PublicKey) (ed25519.PublicKey, error) {
+	if _, ok, err := storage.GetPublicKey(ctx, db, name); err != nil {
+		return nil, err
+	} else if ok {
+		return nil, ErrDuplicateKeyName
+	}
+
+	pub := preset
+	if pub == nil {
+		var err error
+		pub, _, err = ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := storage.SetPublicKey(ctx, db, name, pub); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// keyCreateSecp256k1Func is the KeySecp256k1 counterpart to keyCreateFunc.
+func keyCreateSecp256k1Func(ctx context.Context, db *state.SimpleMutable, name string, preset []byte) (storage.Secp256k1PublicKey, error) {
+	var pub storage.Secp256k1PublicKey
+	if _, ok, err := storage.GetPublicKeySecp256k1(ctx, db, name); err != nil {
+		return pub, err
+	} else if ok {
+		return pub, ErrDuplicateKeyName
+	}
+
+	if preset != nil {
+		copy(pub[:], preset)
+	} else if _, err := rand.Read(pub[:]); err != nil {
+		return pub, err
+	}
+	if err := storage.SetPublicKeySecp256k1(ctx, db, name, pub); err != nil {
+		return pub, err
+	}
+	return pub, nil
+}
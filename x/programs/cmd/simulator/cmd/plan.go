@@ -6,7 +6,9 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"crypto/ed25519"
 	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -28,19 +30,59 @@ import (
 
 var _ Cmd = (*runCmd)(nil)
 
+// ErrStepTimeout is the error (wrapped) that RunStep records in a step's
+// Response when the step's Timeout elapses before its execution completes,
+// so plan authors can distinguish a deliberate cancellation from a program
+// error.
+var ErrStepTimeout = errors.New("step timed out")
+
 type runCmd struct {
 	cmd *argparse.Command
 
 	lastStep *int
 	file     *string
 	planStep *string
+	dryRun   *bool
+
+	// steps holds every step in the plan, in execution order. A single step
+	// (a --step or --file holding one JSON object rather than an array) is
+	// just a one-element plan, so the rest of runCmd doesn't need to
+	// special-case it.
+	steps []*namedStep
+	// namesToIndex resolves a step's optional name to its step index N (the
+	// same index its "step_N" identifier uses) so later steps can reference
+	// it (e.g. "token_program") instead of the positional "step_N" identifier.
+	namesToIndex map[string]int
 
-	step   *Step
 	log    logging.Logger
 	reader *bufio.Reader
 
 	// tracks program IDs created during this simulation
 	programIDStrMap map[int]ids.ID
+
+	// journal records every executed step for replay on the next simulator
+	// startup. It's optional: a runCmd without one (e.g. in tests) just
+	// skips recording.
+	journal *Journal
+}
+
+// NewRunCmd returns a Cmd implementing `simulator run`.
+func NewRunCmd() *runCmd {
+	return &runCmd{}
+}
+
+// SetJournal attaches a journal so every step this runCmd executes is
+// recorded to it.
+func (c *runCmd) SetJournal(j *Journal) {
+	c.journal = j
+}
+
+// namedStep pairs a Step with the optional name it was given in a plan file,
+// so createCallParams can resolve references to it by name in addition to
+// the positional step_N form.
+type namedStep struct {
+	name string
+	step *Step
 }
 
 func (c *runCmd) New(parser *argparse.Parser, programIDStrMap map[int]ids.ID, lastStep *int, reader *bufio.Reader) {
@@ -52,6 +94,10 @@ func (c *runCmd) New(parser *argparse.Parser, programIDStrMap map[int]ids.ID, la
 	c.planStep = c.cmd.String("", "step", &argparse.Options{
 		Required: false,
 	})
+	c.dryRun = c.cmd.Flag("", "dry-run", &argparse.Options{
+		Required: false,
+		Help:     "simulate EndpointExecute steps against a snapshot and revert afterwards instead of committing them",
+	})
 	c.lastStep = lastStep
 	c.reader = reader
 }
@@ -65,11 +111,23 @@ func (c *runCmd) Run(ctx context.Context, log logging.Logger, db *state.SimpleMu
 	if err = c.Verify(); err != nil {
 		return newResponse(0), err
 	}
-	resp, err := c.RunStep(ctx, db)
-	if err != nil {
-		return newResponse(0), err
+
+	// Run every step in the plan within this single invocation, threading
+	// programIDStrMap and lastStep across them so a later step can reference
+	// a program created by an earlier one without a shell driver re-invoking
+	// `run` once per step. Every step's Response is folded into agg.Steps so
+	// callers can inspect the whole plan's results, not just the last step's.
+	agg := newResponse(0)
+	for _, ns := range c.steps {
+		resp, runErr := c.RunStep(ctx, db, ns)
+		if resp != nil {
+			agg.addStep(resp)
+		}
+		if runErr != nil {
+			return agg, runErr
+		}
 	}
-	return resp, nil
+	return agg, nil
 }
 
 func (c *runCmd) Happened() bool {
@@ -77,44 +135,88 @@ func (c *runCmd) Happened() bool {
 }
 
 func (c *runCmd) Init() (err error) {
-	var planStep []byte
+	var planBytes []byte
 	switch {
 	case c.planStep != nil && len(*c.planStep) > 0:
 		{
-			planStep = []byte(*c.planStep)
+			planBytes = []byte(*c.planStep)
 		}
 	case len(*c.file) > 0:
 		{
-			// read simulation step from file
-			planStep, err = os.ReadFile(*c.file)
+			// read simulation plan (or single step) from file
+			planBytes, err = os.ReadFile(*c.file)
 			if err != nil {
 				return err
 			}
 		}
 	default:
-		return errors.New("please specify either a --plan or a --file flag")
+		return errors.New("please specify either a --step or a --file flag")
 	}
 
-	c.step, err = unmarshalStep(planStep)
+	c.steps, err = unmarshalPlan(planBytes)
 	if err != nil {
 		return err
 	}
 
+	c.namesToIndex = make(map[string]int, len(c.steps))
+	for i, ns := range c.steps {
+		if ns.name != "" {
+			c.namesToIndex[ns.name] = *c.lastStep + i
+		}
+	}
+
 	return nil
 }
 
+// unmarshalPlan parses the contents of --step/--file as either a full plan
+// (a JSON array of steps, each optionally carrying a "name" used to resolve
+// references from later steps) or a single step, for backwards compatibility
+// with the one-step-at-a-time invocation this command used to be limited to.
+func unmarshalPlan(data []byte) ([]*namedStep, error) {
+	var rawSteps []json.RawMessage
+	if err := json.Unmarshal(data, &rawSteps); err != nil {
+		step, err := unmarshalStep(data)
+		if err != nil {
+			return nil, err
+		}
+		return []*namedStep{{step: step}}, nil
+	}
+
+	steps := make([]*namedStep, 0, len(rawSteps))
+	for i, raw := range rawSteps {
+		step, err := unmarshalStep(raw)
+		if err != nil {
+			return nil, fmt.Errorf("plan step %d: %w", i, err)
+		}
+		var meta struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return nil, fmt.Errorf("plan step %d: %w", i, err)
+		}
+		steps = append(steps, &namedStep{name: meta.Name, step: step})
+	}
+	return steps, nil
+}
+
 func (c *runCmd) Verify() error {
-	step := c.step
-	if step == nil {
+	if len(c.steps) == 0 {
 		return fmt.Errorf("%w: %s", ErrInvalidPlan, "no steps found")
 	}
 
-	if step.Params == nil {
-		return fmt.Errorf("%w: %s", ErrInvalidStep, "no params found")
+	for i, ns := range c.steps {
+		step := ns.step
+		if step.Params == nil {
+			return fmt.Errorf("%w: %s", ErrInvalidStep, "no params found")
+		}
+
+		// verify endpoint requirements
+		if err := verifyEndpoint(*c.lastStep+i, step); err != nil {
+			return err
+		}
 	}
 
-	// verify endpoint requirements
-	return verifyEndpoint(*c.lastStep, step)
+	return nil
 }
 
 func verifyEndpoint(i int, step *Step) error {
@@ -149,9 +251,9 @@ func verifyEndpoint(i int, step *Step) error {
 	return nil
 }
 
-func (c *runCmd) RunStep(ctx context.Context, db *state.SimpleMutable) (*Response, error) {
+func (c *runCmd) RunStep(ctx context.Context, db *state.SimpleMutable, ns *namedStep) (*Response, error) {
 	index := *c.lastStep
-	step := c.step
+	step := ns.step
 	c.log.Info("simulation",
 		zap.Int("step", index),
 		zap.String("endpoint", string(step.Endpoint)),
@@ -160,16 +262,45 @@ func (c *runCmd) RunStep(ctx context.Context, db *state.SimpleMutable) (*Respons
 		zap.Any("params", step.Params),
 	)
 
-	params, err := c.createCallParams(ctx, db, step.Params, step.Endpoint)
+	// Derive a child context bounded by the step's deadline, the same way
+	// netstack's gonet adapter times out a blocking read: a done channel
+	// closed on whichever happens first, the timer firing or the parent
+	// being cancelled. programExecuteFunc selects on ctx.Done() and, when it
+	// fires, calls the runtime's Interrupt and waits for the call to return.
+	stepCtx := ctx
+	if step.Timeout > 0 {
+		var cancel context.CancelFunc
+		stepCtx, cancel = context.WithTimeout(ctx, step.Timeout)
+		defer cancel()
+	}
+
+	params, err := c.createCallParams(stepCtx, db, step.Params, step.Endpoint)
 	if err != nil {
 		c.log.Error(fmt.Sprintf("simulation call: %s", err))
 		return newResponse(0), err
 	}
 
+	// Read-only calls must never mutate the DB, even if the program they
+	// invoke tries to write: snapshot before running and always revert. A
+	// --dry-run execute step borrows the same mechanism to speculatively run
+	// against live state (printing gas/response) without polluting it.
+	speculative := step.Endpoint == EndpointReadOnly || (c.dryRun != nil && *c.dryRun && step.Endpoint == EndpointExecute)
+	var snapshotID int
+	if speculative {
+		snapshotID = db.Snapshot()
+	}
+
 	resp := newResponse(index)
-	err = runStepFunc(ctx, c.log, db, step.Endpoint, step.MaxUnits, step.Method, params, resp)
-	if err != nil {
-		resp.setError(err)
+	stepErr := runStepFunc(stepCtx, c.log, db, step.Endpoint, step.MaxUnits, step.Method, params, nil, resp)
+	if stepErr != nil {
+		if errors.Is(stepCtx.Err(), context.DeadlineExceeded) {
+			stepErr = fmt.Errorf("%w: step %d exceeded %s", ErrStepTimeout, index, step.Timeout)
+		}
+		resp.setError(stepErr)
+	}
+
+	if speculative {
+		db.RevertToSnapshot(snapshotID)
 	}
 
 	// map all transactions to their step_N identifier
@@ -182,12 +313,36 @@ func (c *runCmd) RunStep(ctx context.Context, db *state.SimpleMutable) (*Respons
 		c.programIDStrMap[index] = id
 	}
 
+	// Speculative steps (read-only calls, --dry-run executes) are reverted
+	// above and must never be journaled: replaying them would re-run them for
+	// real and commit their effects, directly polluting durable state. A
+	// step that errored must never be journaled either: runStepFunc is
+	// deterministic, so replaying it would error again at the same point and
+	// Replay would never get past it, permanently bricking simulator startup.
+	if c.journal != nil && !speculative && stepErr == nil {
+		key, _ := resp.getKey()
+		if err := c.journal.Append(JournalEntry{
+			Step:     index,
+			Endpoint: step.Endpoint,
+			Method:   step.Method,
+			Params:   params,
+			TxID:     txID,
+			Key:      key,
+		}); err != nil {
+			return resp, err
+		}
+	}
+
 	lastStep := index + 1
 	*c.lastStep = lastStep
 
 	return resp, nil
 }
 
+// runStepFunc executes a single resolved call. presetKey, when non-nil, is
+// restored as-is for an EndpointKey step instead of generating fresh key
+// material — Replay passes the key bytes recorded in the JournalEntry so a
+// replayed key step reproduces the original run's public key exactly.
 func runStepFunc(
 	ctx context.Context,
 	log logging.Logger,
@@ -196,19 +351,37 @@ func runStepFunc(
 	maxUnits uint64,
 	method string,
 	params []Parameter,
+	presetKey []byte,
 	resp *Response,
 ) error {
 	defer resp.setTimestamp(time.Now().Unix())
 	switch endpoint {
 	case EndpointKey:
 		keyName := string(params[0].Value)
-		key, err := keyCreateFunc(ctx, db, keyName)
-		if errors.Is(err, ErrDuplicateKeyName) {
-			log.Debug("key already exists")
-		} else if err != nil {
-			return err
+		var addr string
+		switch params[0].Type {
+		case KeySecp256k1:
+			key, err := keyCreateSecp256k1Func(ctx, db, keyName, presetKey)
+			if errors.Is(err, ErrDuplicateKeyName) {
+				log.Debug("key already exists")
+			} else if err != nil {
+				return err
+			} else {
+				resp.setKey(key[:])
+			}
+			addr = utils.AddressSecp256k1(key)
+		default:
+			key, err := keyCreateFunc(ctx, db, keyName, ed25519.PublicKey(presetKey))
+			if errors.Is(err, ErrDuplicateKeyName) {
+				log.Debug("key already exists")
+			} else if err != nil {
+				return err
+			} else {
+				resp.setKey(key)
+			}
+			addr = utils.Address(key)
 		}
-		resp.setMsg("created named key with address " + utils.Address(key))
+		resp.setMsg("created named key with address " + addr)
 
 		return nil
 	case EndpointExecute: // for now the logic is the same for both TODO: breakout readonly
@@ -233,12 +406,10 @@ func runStepFunc(
 			return err
 		}
 
-		if len(response) > 1 {
-			return errors.New("multi response not supported")
-		}
-		res := response[0]
-		if res != nil {
-			resp.setResponse(res)
+		for _, res := range response {
+			if res != nil {
+				resp.setResponse(res)
+			}
 		}
 		resp.setTxID(id.String())
 		resp.setBalance(balance)
@@ -249,18 +420,17 @@ func runStepFunc(
 		if err != nil {
 			return err
 		}
-		// TODO: implement readonly for now just don't charge for gas
+		// TODO: charge for gas; mutation is already prevented by the
+		// snapshot/revert wrapping this call in RunStep
 		_, response, _, err := programExecuteFunc(ctx, log, db, id, params[1:], method, math.MaxUint64)
 		if err != nil {
 			return err
 		}
 
-		if len(response) > 1 {
-			return errors.New("multi response not supported")
-		}
-		res := response[0]
-		if res != nil {
-			resp.setResponse(res)
+		for _, res := range response {
+			if res != nil {
+				resp.setResponse(res)
+			}
 		}
 
 		return nil
@@ -277,7 +447,14 @@ func (c *runCmd) createCallParams(ctx context.Context, db state.Immutable, param
 		case String, ID:
 			stepIDStr := string(param.Value)
 			idString, found := strings.CutPrefix(stepIDStr, "step_")
-			if found {
+			if idx, ok := c.namesToIndex[stepIDStr]; ok {
+				// named reference, e.g. "token_program" instead of "step_2"
+				programID, ok := c.programIDStrMap[idx]
+				if !ok {
+					return nil, fmt.Errorf("failed to map to id: %s", stepIDStr)
+				}
+				cp = append(cp, Parameter{Value: programID[:], Type: param.Type})
+			} else if found {
 				id, err := strconv.ParseInt(idString, 10, 32)
 				if err != nil {
 					return nil, err
@@ -299,7 +476,7 @@ func (c *runCmd) createCallParams(ctx context.Context, db state.Immutable, param
 					cp = append(cp, param)
 				}
 			}
-		case KeyEd25519: // TODO: support secp256k1
+		case KeyEd25519:
 			key := string(param.Value)
 			// get named public key from db
 			pk, ok, err := storage.GetPublicKey(ctx, db, key)
@@ -317,9 +494,26 @@ func (c *runCmd) createCallParams(ctx context.Context, db state.Immutable, param
 				copy(address[1:], pk[:])
 				key = string(address)
 			}
+			cp = append(cp, Parameter{Value: []byte(key), Type: param.Type})
+		case KeySecp256k1:
+			key := string(param.Value)
+			// get named public key from db
+			pk, ok, err := storage.GetPublicKeySecp256k1(ctx, db, key)
 			if err != nil {
 				return nil, err
 			}
+			if !ok && endpoint != EndpointKey {
+				// using not stored named public key in other context than key creation
+				return nil, fmt.Errorf("%w: %s", ErrNamedKeyNotFound, key)
+			}
+			if ok {
+				// otherwise use the public key address, distinguished from an
+				// ed25519 address by its prefix byte
+				address := make([]byte, codec.AddressLen)
+				address[0] = 1 // prefix
+				copy(address[1:], pk[:])
+				key = string(address)
+			}
 			cp = append(cp, Parameter{Value: []byte(key), Type: param.Type})
 		case Uint64, Bool:
 			cp = append(cp, param)
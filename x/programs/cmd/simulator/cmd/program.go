@@ -0,0 +1,101 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const programKeyPrefix byte = 0x2
+
+func programKey(id ids.ID) []byte {
+	return append([]byte{programKeyPrefix}, id[:]...)
+}
+
+// programCreateFunc deploys the program at programPath, storing its bytes
+// under a freshly generated program ID.
+func programCreateFunc(ctx context.Context, db *state.SimpleMutable, programPath string) (ids.ID, error) {
+	programBytes, err := os.ReadFile(programPath)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	id, err := generateRandomID()
+	if err != nil {
+		return ids.Empty, err
+	}
+	if err := db.Insert(ctx, programKey(id), programBytes); err != nil {
+		return ids.Empty, err
+	}
+	return id, nil
+}
+
+// programRuntime executes a single call against a loaded program. It is the
+// seam programExecuteFunc uses to signal cancellation to a call; the only
+// implementation here is wasmRuntime (see runtime.go), which does not execute
+// WASM bytecode.
+type programRuntime interface {
+	Call(method string, params []Parameter, maxUnits uint64) ([]Result, uint64, error)
+	// Interrupt marks the runtime as interrupted. wasmRuntime checks this
+	// only when a Call starts; a Call already under way runs to completion.
+	Interrupt()
+}
+
+// loadProgramRuntime loads the program stored under id and returns a
+// runtime ready to Call it.
+func loadProgramRuntime(ctx context.Context, db *state.SimpleMutable, id ids.ID) (programRuntime, error) {
+	programBytes, err := db.GetValue(ctx, programKey(id))
+	if err != nil {
+		return nil, err
+	}
+	return newWasmRuntime(programBytes), nil
+}
+
+// programExecuteFunc calls method on the program stored under id. The call
+// runs on its own goroutine so that if ctx is cancelled (e.g. the step's
+// Timeout elapses) before it returns, the runtime is interrupted instead of
+// left to run unbounded.
+func programExecuteFunc(
+	ctx context.Context,
+	log logging.Logger,
+	db *state.SimpleMutable,
+	id ids.ID,
+	params []Parameter,
+	method string,
+	maxUnits uint64,
+) (ids.ID, []Result, uint64, error) {
+	rt, err := loadProgramRuntime(ctx, db, id)
+	if err != nil {
+		return ids.Empty, nil, 0, err
+	}
+
+	type callResult struct {
+		response []Result
+		balance  uint64
+		err      error
+	}
+	done := make(chan callResult, 1)
+	go func() {
+		response, balance, err := rt.Call(method, params, maxUnits)
+		done <- callResult{response, balance, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return ids.Empty, nil, 0, res.err
+		}
+		return id, res.response, res.balance, nil
+	case <-ctx.Done():
+		rt.Interrupt()
+		<-done // wait for Call to observe the interrupt and return
+		return ids.Empty, nil, 0, ctx.Err()
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+// Result is a single value returned by a program call.
+type Result []byte
+
+// response is the serializable form of Response.
+type response struct {
+	Step      int      `json:"step"`
+	Timestamp int64    `json:"timestamp"`
+	Msg       string   `json:"msg,omitempty"`
+	TxID      string   `json:"txId,omitempty"`
+	Balance   uint64   `json:"balance,omitempty"`
+	Response  []Result `json:"response,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	// Key holds the public key material an EndpointKey step generated, if
+	// any, so the journal can record it and Replay can restore the exact
+	// same key instead of generating new (and different) key material.
+	Key []byte `json:"key,omitempty"`
+	// Steps holds each step's own result when this Response is the aggregate
+	// returned by runCmd.Run across a multi-step plan.
+	Steps []response `json:"steps,omitempty"`
+}
+
+// Response is the result of running a single step, or, with Steps set, the
+// aggregate of a whole plan returned by runCmd.Run.
+type Response struct {
+	response
+}
+
+func newResponse(step int) *Response {
+	return &Response{response: response{Step: step}}
+}
+
+func (r *Response) setMsg(msg string)     { r.Msg = msg }
+func (r *Response) setTxID(txID string)   { r.TxID = txID }
+func (r *Response) setBalance(bal uint64) { r.Balance = bal }
+func (r *Response) setTimestamp(ts int64) { r.Timestamp = ts }
+
+func (r *Response) setError(err error) {
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// setResponse appends a single result, so a program returning a tuple
+// serializes every element instead of only the last.
+func (r *Response) setResponse(res Result) {
+	r.Response = append(r.Response, res)
+}
+
+func (r *Response) getTxID() (string, bool) {
+	return r.TxID, r.TxID != ""
+}
+
+func (r *Response) setKey(key []byte)      { r.Key = key }
+func (r *Response) getKey() ([]byte, bool) { return r.Key, r.Key != nil }
+
+// addStep appends step's result to r.Steps, so a multi-step plan's Run can
+// return every step's result instead of just the last one.
+func (r *Response) addStep(step *Response) {
+	r.Steps = append(r.Steps, step.response)
+}
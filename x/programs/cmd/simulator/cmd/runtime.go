@@ -0,0 +1,32 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import "sync/atomic"
+
+// wasmRuntime is a minimal stand-in for the wasmtime-backed program runtime:
+// it doesn't execute WASM bytecode. Interrupt sets a flag that Call checks
+// once, before it does anything else; a Call that has already passed that
+// check is not stopped by it.
+type wasmRuntime struct {
+	programBytes []byte
+	interrupted  atomic.Bool
+}
+
+func newWasmRuntime(programBytes []byte) *wasmRuntime {
+	return &wasmRuntime{programBytes: programBytes}
+}
+
+func (r *wasmRuntime) Call(method string, params []Parameter, maxUnits uint64) ([]Result, uint64, error) {
+	if r.interrupted.Load() {
+		return nil, 0, ErrStepTimeout
+	}
+	// TODO: actually invoke the WASM runtime; for now this just proves out
+	// the call's plumbing and the interrupt contract above.
+	return []Result{[]byte(method)}, maxUnits, nil
+}
+
+func (r *wasmRuntime) Interrupt() {
+	r.interrupted.Store(true)
+}
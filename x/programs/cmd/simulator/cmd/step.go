@@ -0,0 +1,59 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Endpoint identifies which simulator subsystem a step targets.
+type Endpoint string
+
+const (
+	EndpointKey      Endpoint = "key"
+	EndpointExecute  Endpoint = "execute"
+	EndpointReadOnly Endpoint = "readonly"
+)
+
+// ParamType identifies how a Parameter's raw bytes should be interpreted.
+type ParamType string
+
+const (
+	String       ParamType = "string"
+	ID           ParamType = "id"
+	KeyEd25519   ParamType = "ed25519"
+	KeySecp256k1 ParamType = "secp256k1"
+	Uint64       ParamType = "u64"
+	Bool         ParamType = "bool"
+)
+
+// ProgramCreate is the Method value used to deploy a new program.
+const ProgramCreate = "program_create"
+
+// Parameter is a single argument to a step's call.
+type Parameter struct {
+	Value []byte    `json:"value"`
+	Type  ParamType `json:"type"`
+}
+
+// Step is one call the simulator executes: create a key, deploy a program,
+// execute it, or read from it.
+type Step struct {
+	Endpoint Endpoint    `json:"endpoint"`
+	Method   string      `json:"method"`
+	MaxUnits uint64      `json:"maxUnits"`
+	Params   []Parameter `json:"params"`
+	// Timeout bounds how long this step may run; zero means no deadline.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// unmarshalStep parses a single step from JSON.
+func unmarshalStep(data []byte) (*Step, error) {
+	step := new(Step)
+	if err := json.Unmarshal(data, step); err != nil {
+		return nil, err
+	}
+	return step, nil
+}
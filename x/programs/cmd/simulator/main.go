@@ -0,0 +1,84 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/akamensky/argparse"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/programs/cmd/simulator/cmd"
+)
+
+// dataDir is where the simulator keeps its persistent step journal between
+// invocations.
+const dataDir = "."
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx := context.Background()
+	log := logging.NewLogger(
+		"simulator",
+		logging.NewWrappedCore(logging.Info, os.Stdout, logging.Colors.ConsoleEncoder()),
+	)
+
+	db := state.NewSimpleMutable(nil)
+
+	// Replay whatever the journal recorded on a previous run so programIDStrMap
+	// and db pick up right where the simulator last left off, instead of
+	// requiring a caller to replay it manually before issuing new commands.
+	programIDStrMap, lastStep, err := cmd.Replay(ctx, log, db, dataDir)
+	if err != nil {
+		return fmt.Errorf("replaying journal: %w", err)
+	}
+
+	journal, err := cmd.OpenJournal(dataDir, log)
+	if err != nil {
+		return fmt.Errorf("opening journal: %w", err)
+	}
+	defer journal.Close()
+
+	parser := argparse.NewParser("simulator", "HyperSDK program simulator")
+	reader := bufio.NewReader(os.Stdin)
+
+	runC := cmd.NewRunCmd()
+	runC.New(parser, programIDStrMap, &lastStep, reader)
+	runC.SetJournal(journal)
+
+	if err := parser.Parse(os.Args); err != nil {
+		return errors.New(parser.Usage(err))
+	}
+
+	cmds := []cmd.Cmd{runC}
+	for _, c := range cmds {
+		if !c.Happened() {
+			continue
+		}
+		resp, err := c.Run(ctx, log, db, os.Args)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	return errors.New("please specify a command, e.g. `run`")
+}
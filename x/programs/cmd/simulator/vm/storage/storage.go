@@ -0,0 +1,68 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package storage reads and writes the simulator's named public keys against
+// state.Immutable/state.Mutable.
+package storage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// Secp256k1PublicKey holds secp256k1 public key material sized to fit
+// codec.AddressLen alongside its prefix byte. It stands in for a real
+// secp256k1 keypair (e.g. decred/dcrd's secp256k1 package) until that
+// dependency is added; the simulator only needs it to round-trip through
+// storage and addressing today.
+type Secp256k1PublicKey [32]byte
+
+const (
+	keyPrefix          byte = 0x0
+	secp256k1KeyPrefix byte = 0x1
+)
+
+func pkKey(name string) []byte {
+	return append([]byte{keyPrefix}, []byte(name)...)
+}
+
+func secp256k1PkKey(name string) []byte {
+	return append([]byte{secp256k1KeyPrefix}, []byte(name)...)
+}
+
+// GetPublicKey looks up the ed25519 public key stored under name.
+func GetPublicKey(ctx context.Context, db state.Immutable, name string) (ed25519.PublicKey, bool, error) {
+	v, err := db.GetValue(ctx, pkKey(name))
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return ed25519.PublicKey(v), true, nil
+}
+
+// SetPublicKey stores the ed25519 public key pk under name.
+func SetPublicKey(ctx context.Context, db state.Mutable, name string, pk ed25519.PublicKey) error {
+	return db.Insert(ctx, pkKey(name), pk)
+}
+
+// GetPublicKeySecp256k1 is the KeySecp256k1 counterpart to GetPublicKey.
+func GetPublicKeySecp256k1(ctx context.Context, db state.Immutable, name string) (Secp256k1PublicKey, bool, error) {
+	var pk Secp256k1PublicKey
+	v, err := db.GetValue(ctx, secp256k1PkKey(name))
+	if errors.Is(err, state.ErrNotFound) {
+		return pk, false, nil
+	} else if err != nil {
+		return pk, false, err
+	}
+	copy(pk[:], v)
+	return pk, true, nil
+}
+
+// SetPublicKeySecp256k1 stores the secp256k1 public key pk under name.
+func SetPublicKeySecp256k1(ctx context.Context, db state.Mutable, name string, pk Secp256k1PublicKey) error {
+	return db.Insert(ctx, secp256k1PkKey(name), pk[:])
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package utils holds small formatting helpers shared across the simulator.
+package utils
+
+import (
+	"crypto/ed25519"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/x/programs/cmd/simulator/vm/storage"
+)
+
+// Address renders an ed25519 public key as a simulator address: a prefix
+// byte identifying the key type, followed by the key itself. This is the
+// same layout createCallParams builds when resolving a named key to an
+// address.
+func Address(pk ed25519.PublicKey) string {
+	address := make([]byte, codec.AddressLen)
+	address[0] = 0
+	copy(address[1:], pk)
+	return string(address)
+}
+
+// AddressSecp256k1 is the KeySecp256k1 counterpart to Address, distinguished
+// from an ed25519 address by its prefix byte.
+func AddressSecp256k1(pk storage.Secp256k1PublicKey) string {
+	address := make([]byte, codec.AddressLen)
+	address[0] = 1
+	copy(address[1:], pk[:])
+	return string(address)
+}